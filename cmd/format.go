@@ -0,0 +1,269 @@
+//
+// Copyright (c) 2021 SSH Communications Security Inc.
+//
+// All rights reserved.
+//
+
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+type formatOptions struct {
+	output   string
+	fields   []string
+	template string
+}
+
+var outputOptions = formatOptions{}
+
+func init() {
+	flags := rootCmd.PersistentFlags()
+	flags.StringVar(&outputOptions.output, "output", "json", "output format, one of: json, yaml, table, csv, template")
+	flags.StringArrayVar(&outputOptions.fields, "fields", []string{}, "comma separated list of fields to print, e.g. id,username,roles[].name")
+	flags.StringVar(&outputOptions.template, "template", "", "go template used to render the result, implies --output template")
+}
+
+// render prints data using the formatter selected by --output/--fields/--template,
+// falling back to the plain JSON dump used throughout the rest of the CLI.
+// defaultColumns, when given, is the sensible per-subcommand column set
+// (e.g. id,username,email,source_id for users list) used by --output table/csv when
+// the caller did not ask for specific --fields.
+func render(data interface{}, defaultColumns ...string) error {
+	if outputOptions.template != "" {
+		return renderTemplate(data, outputOptions.template)
+	}
+
+	fields := flattenFields(outputOptions.fields)
+	if len(fields) == 0 && (outputOptions.output == "" || outputOptions.output == "json") {
+		return stdout(data)
+	}
+
+	columns := fields
+	if len(columns) == 0 {
+		columns = defaultColumns
+	}
+
+	if len(fields) > 0 {
+		projected, err := projectFields(data, fields)
+		if err != nil {
+			return err
+		}
+		data = projected
+	}
+
+	switch outputOptions.output {
+	case "", "json":
+		return stdout(data)
+	case "yaml":
+		return renderYAML(data)
+	case "table":
+		return renderTable(data, columns)
+	case "csv":
+		return renderCSV(data, columns)
+	case "template":
+		return fmt.Errorf("--output template requires --template")
+	default:
+		return fmt.Errorf("unsupported output format %q", outputOptions.output)
+	}
+}
+
+func flattenFields(fields []string) []string {
+	flat := []string{}
+	for _, field := range fields {
+		for _, part := range strings.Split(field, ",") {
+			part = strings.TrimSpace(part)
+			if part != "" {
+				flat = append(flat, part)
+			}
+		}
+	}
+	return flat
+}
+
+func renderTemplate(data interface{}, text string) error {
+	tmpl, err := template.New("output").Parse(text)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(os.Stdout, data)
+}
+
+func renderYAML(data interface{}) error {
+	out, err := yaml.Marshal(data)
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(out))
+	return nil
+}
+
+func renderTable(data interface{}, fields []string) error {
+	rows, header := tableRows(data, fields)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+func renderCSV(data interface{}, fields []string) error {
+	rows, header := tableRows(data, fields)
+
+	w := csv.NewWriter(os.Stdout)
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// tableRows turns data into a header row plus string cells, using fields as
+// the column order when given, or the union of keys across all records
+// otherwise (so a column only set on some rows, e.g. an "error" column only
+// populated for failed entries, is never silently dropped).
+func tableRows(data interface{}, fields []string) (rows [][]string, header []string) {
+	records := toRecords(data)
+
+	header = fields
+	if len(header) == 0 && len(records) > 0 {
+		seen := map[string]bool{}
+		for _, record := range records {
+			for key := range record {
+				if !seen[key] {
+					seen[key] = true
+					header = append(header, key)
+				}
+			}
+		}
+		sort.Strings(header)
+	}
+
+	for _, record := range records {
+		row := make([]string, 0, len(header))
+		for _, key := range header {
+			row = append(row, fmt.Sprint(record[key]))
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, header
+}
+
+// toRecords normalizes data (a struct, a slice of structs, or their
+// map/interface{} equivalents) into a slice of string-keyed records.
+func toRecords(data interface{}) []map[string]interface{} {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil
+	}
+
+	switch v := generic.(type) {
+	case []interface{}:
+		records := make([]map[string]interface{}, 0, len(v))
+		for _, item := range v {
+			if m, ok := item.(map[string]interface{}); ok {
+				records = append(records, m)
+			}
+		}
+		return records
+	case map[string]interface{}:
+		return []map[string]interface{}{v}
+	default:
+		return nil
+	}
+}
+
+// projectFields keeps only the requested fields of data. A field may use
+// dotted paths and a trailing "[]" to descend into nested arrays, e.g.
+// "roles[].name".
+func projectFields(data interface{}, fields []string) (interface{}, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil, err
+	}
+
+	if items, ok := generic.([]interface{}); ok {
+		projected := make([]interface{}, 0, len(items))
+		for _, item := range items {
+			projected = append(projected, projectRecord(item, fields))
+		}
+		return projected, nil
+	}
+
+	return projectRecord(generic, fields), nil
+}
+
+func projectRecord(value interface{}, fields []string) map[string]interface{} {
+	record := map[string]interface{}{}
+	for _, field := range fields {
+		record[field] = selectField(value, field)
+	}
+	return record
+}
+
+func selectField(value interface{}, path string) interface{} {
+	if path == "" {
+		return value
+	}
+
+	parts := strings.SplitN(path, ".", 2)
+	head, rest := parts[0], ""
+	if len(parts) == 2 {
+		rest = parts[1]
+	}
+
+	array := strings.HasSuffix(head, "[]")
+	head = strings.TrimSuffix(head, "[]")
+
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	next, ok := m[head]
+	if !ok {
+		return nil
+	}
+
+	if array {
+		items, ok := next.([]interface{})
+		if !ok {
+			return nil
+		}
+		result := make([]interface{}, 0, len(items))
+		for _, item := range items {
+			result = append(result, selectField(item, rest))
+		}
+		return result
+	}
+
+	return selectField(next, rest)
+}
@@ -7,14 +7,23 @@
 package cmd
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/SSHcom/privx-sdk-go/api/rolestore"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 type userOptions struct {
@@ -26,8 +35,73 @@ type userOptions struct {
 	keywords       []string
 	userRoleGrant  []string
 	userRoleRevoke []string
+	file           string
+	format         string
+	dryRun         bool
+	roles          []string
+	roleMatch      string
+	source         string
+	mfaEnabled     bool
+	mfaDisabled    bool
+	limit          int
+	offset         int
+	sort           string
+	prune          bool
+	continueOnErr  bool
+	watch          bool
+	interval       time.Duration
+	concurrency    int
+	failFast       bool
 }
 
+// idResult is the structured per-ID outcome of a concurrent fan-out call.
+type idResult struct {
+	ID    string `json:"id"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// runBounded runs task for every index in [0,n) through a bounded worker
+// pool (errgroup-style concurrency, capped at concurrency), blocking until
+// every task has finished.
+func runBounded(n, concurrency int, task func(i int)) {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			task(i)
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+func reportErr(results []idResult) error {
+	failed := 0
+	for _, result := range results {
+		if !result.OK {
+			failed++
+		}
+	}
+	if failed == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%d of %d IDs failed, see report for details", failed, len(results))
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
 func init() {
 	rootCmd.AddCommand(userListCmd())
 }
@@ -43,6 +117,10 @@ func userListCmd() *cobra.Command {
 		Long:  `List and manage users`,
 		Example: `
 	privx-cli users [access flags] --keywords <KEYWORD>,<KEYWORD>
+	privx-cli users [access flags] --role <ROLE-ID-OR-NAME> --role-match all
+	privx-cli users [access flags] --source <SOURCE-ID> --mfa-enabled
+	privx-cli users [access flags] --limit 100 --offset 200 --sort username
+	privx-cli users [access flags] --watch --interval 10s
 		`,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -52,6 +130,16 @@ func userListCmd() *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.StringArrayVarP(&options.keywords, "keywords", "", []string{}, "search keywords")
+	flags.StringArrayVar(&options.roles, "role", []string{}, "filter by role, id or name, repeatable")
+	flags.StringVar(&options.roleMatch, "role-match", "any", "match mode for multiple --role values, any or all")
+	flags.StringVar(&options.source, "source", "", "filter by user source ID")
+	flags.BoolVar(&options.mfaEnabled, "mfa-enabled", false, "only show users with multifactor authentication enabled")
+	flags.BoolVar(&options.mfaDisabled, "mfa-disabled", false, "only show users with multifactor authentication disabled")
+	flags.IntVar(&options.limit, "limit", 0, "maximum number of users per page, 0 shows all users in a single page")
+	flags.IntVar(&options.offset, "offset", 0, "number of users to skip before the first page")
+	flags.StringVar(&options.sort, "sort", "", "sort users by field, username or email")
+	flags.BoolVar(&options.watch, "watch", false, "watch for changes and stream them as newline-delimited JSON events")
+	flags.DurationVar(&options.interval, "interval", 5*time.Second, "polling interval used with --watch")
 
 	cmd.AddCommand(userShowCmd())
 	cmd.AddCommand(userSettingShowCmd())
@@ -59,6 +147,7 @@ func userListCmd() *cobra.Command {
 	cmd.AddCommand(usersRolesCmd())
 	cmd.AddCommand(userMFACmd())
 	cmd.AddCommand(externalUserSearchCmd())
+	cmd.AddCommand(userCreateCmd())
 
 	return cmd
 }
@@ -66,12 +155,301 @@ func userListCmd() *cobra.Command {
 func userList(options userOptions) error {
 	api := rolestore.New(curl())
 
-	users, err := api.SearchUsers(strings.Join(options.keywords, ","), "")
+	if options.watch {
+		return watchLoop(options.interval, func() (map[string]watchRecord, error) {
+			return snapshotUserList(api, options)
+		})
+	}
+
+	users, err := api.SearchUsers(strings.Join(options.keywords, ","), options.source)
+	if err != nil {
+		return err
+	}
+
+	roleIDs, err := resolveRoleNamesOrIDs(api, options.roles)
 	if err != nil {
 		return err
 	}
 
-	return stdout(users)
+	if len(roleIDs) > 0 {
+		users, err = filterUsersByRoles(api, users, roleIDs, options.roleMatch)
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.mfaEnabled {
+		users = filterUsersByMFA(users, true)
+	}
+	if options.mfaDisabled {
+		users = filterUsersByMFA(users, false)
+	}
+
+	if options.sort != "" {
+		sortUsers(users, options.sort)
+	}
+
+	return paginateUsers(users, options.offset, options.limit, func(page interface{}) error {
+		return render(page, "id", "username", "email", "source_id")
+	})
+}
+
+// resolveRoleNamesOrIDs resolves a list of role identifiers, given either as
+// a role name or a role unique id, into role unique ids.
+func resolveRoleNamesOrIDs(api *rolestore.RoleStore, roles []string) ([]string, error) {
+	ids := make([]string, 0, len(roles))
+
+	for _, role := range roles {
+		if uuidPattern.MatchString(role) {
+			ids = append(ids, role)
+			continue
+		}
+
+		found, err := api.Roles(role, "")
+		if err != nil {
+			return nil, err
+		}
+		if len(found) == 0 {
+			return nil, fmt.Errorf("role %q not found", role)
+		}
+
+		ids = append(ids, found[0].ID)
+	}
+
+	return ids, nil
+}
+
+func filterUsersByRoles(api *rolestore.RoleStore, users []rolestore.User, roleIDs []string, match string) ([]rolestore.User, error) {
+	filtered := make([]rolestore.User, 0, len(users))
+
+	for _, user := range users {
+		userRoles, err := api.UserRoles(user.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		has := make(map[string]bool, len(userRoles))
+		for _, role := range userRoles {
+			has[role.ID] = true
+		}
+
+		matched := 0
+		for _, id := range roleIDs {
+			if has[id] {
+				matched++
+			}
+		}
+
+		if (match == "all" && matched == len(roleIDs)) || (match != "all" && matched > 0) {
+			filtered = append(filtered, user)
+		}
+	}
+
+	return filtered, nil
+}
+
+func filterUsersByMFA(users []rolestore.User, enabled bool) []rolestore.User {
+	filtered := make([]rolestore.User, 0, len(users))
+	for _, user := range users {
+		if user.MFAEnabled == enabled {
+			filtered = append(filtered, user)
+		}
+	}
+	return filtered
+}
+
+func sortUsers(users []rolestore.User, field string) {
+	sort.Slice(users, func(i, j int) bool {
+		switch field {
+		case "email":
+			return users[i].Email < users[j].Email
+		default:
+			return users[i].Username < users[j].Username
+		}
+	})
+}
+
+// paginateUsers streams users through emit page-by-page, at most limit users
+// per call; a limit of 0 or less emits all users in a single call.
+func paginateUsers(users []rolestore.User, offset, limit int, emit func(interface{}) error) error {
+	if offset < 0 {
+		return fmt.Errorf("--offset must not be negative")
+	}
+	if offset > len(users) {
+		offset = len(users)
+	}
+	users = users[offset:]
+
+	if limit <= 0 {
+		return emit(users)
+	}
+
+	for len(users) > 0 {
+		page := limit
+		if page > len(users) {
+			page = len(users)
+		}
+
+		if err := emit(users[:page]); err != nil {
+			return err
+		}
+
+		users = users[page:]
+	}
+
+	return nil
+}
+
+// watchRecord is a point-in-time snapshot of a user used to detect changes
+// between polls in --watch mode.
+type watchRecord struct {
+	User    rolestore.User
+	RoleIDs map[string]bool
+}
+
+// watchEvent is one newline-delimited JSON change event emitted by --watch.
+type watchEvent struct {
+	Type string `json:"type"`
+	User string `json:"user"`
+	Role string `json:"role,omitempty"`
+}
+
+func snapshotUser(api *rolestore.RoleStore, user rolestore.User) (watchRecord, error) {
+	roles, err := api.UserRoles(user.ID)
+	if err != nil {
+		return watchRecord{}, err
+	}
+
+	ids := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		ids[role.ID] = true
+	}
+
+	return watchRecord{User: user, RoleIDs: ids}, nil
+}
+
+func snapshotUserList(api *rolestore.RoleStore, options userOptions) (map[string]watchRecord, error) {
+	users, err := api.SearchUsers(strings.Join(options.keywords, ","), options.source)
+	if err != nil {
+		return nil, err
+	}
+
+	roleIDs, err := resolveRoleNamesOrIDs(api, options.roles)
+	if err != nil {
+		return nil, err
+	}
+	if len(roleIDs) > 0 {
+		users, err = filterUsersByRoles(api, users, roleIDs, options.roleMatch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if options.mfaEnabled {
+		users = filterUsersByMFA(users, true)
+	}
+	if options.mfaDisabled {
+		users = filterUsersByMFA(users, false)
+	}
+
+	snapshot := make(map[string]watchRecord, len(users))
+	for _, user := range users {
+		record, err := snapshotUser(api, user)
+		if err != nil {
+			return nil, err
+		}
+		snapshot[user.ID] = record
+	}
+
+	return snapshot, nil
+}
+
+func snapshotUserIDs(api *rolestore.RoleStore, ids []string) (map[string]watchRecord, error) {
+	snapshot := make(map[string]watchRecord, len(ids))
+
+	for _, id := range ids {
+		user, err := api.User(id)
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := snapshotUser(api, *user)
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot[id] = record
+	}
+
+	return snapshot, nil
+}
+
+// diffWatch compares two snapshots and returns the change events between them.
+func diffWatch(prev, next map[string]watchRecord) []watchEvent {
+	events := []watchEvent{}
+
+	for id, nextRecord := range next {
+		prevRecord, existed := prev[id]
+		if !existed {
+			events = append(events, watchEvent{Type: "ADDED", User: id})
+			continue
+		}
+
+		if prevRecord.User.MFAEnabled != nextRecord.User.MFAEnabled {
+			events = append(events, watchEvent{Type: "MFA_CHANGED", User: id})
+		}
+
+		for role := range nextRecord.RoleIDs {
+			if !prevRecord.RoleIDs[role] {
+				events = append(events, watchEvent{Type: "ROLE_GRANTED", User: id, Role: role})
+			}
+		}
+		for role := range prevRecord.RoleIDs {
+			if !nextRecord.RoleIDs[role] {
+				events = append(events, watchEvent{Type: "ROLE_REVOKED", User: id, Role: role})
+			}
+		}
+
+		if !reflect.DeepEqual(prevRecord.User, nextRecord.User) {
+			events = append(events, watchEvent{Type: "MODIFIED", User: id})
+		}
+	}
+
+	for id := range prev {
+		if _, ok := next[id]; !ok {
+			events = append(events, watchEvent{Type: "REMOVED", User: id})
+		}
+	}
+
+	return events
+}
+
+// watchLoop polls snapshot every interval and streams change events as
+// newline-delimited JSON to stdout, similar to `kubectl get --watch`.
+func watchLoop(interval time.Duration, snapshot func() (map[string]watchRecord, error)) error {
+	prev, err := snapshot()
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+
+	for {
+		time.Sleep(interval)
+
+		next, err := snapshot()
+		if err != nil {
+			return err
+		}
+
+		for _, event := range diffWatch(prev, next) {
+			if err := encoder.Encode(event); err != nil {
+				return err
+			}
+		}
+
+		prev = next
+	}
 }
 
 //
@@ -85,6 +463,8 @@ func userShowCmd() *cobra.Command {
 		Long:  `Description about PrivX user. User ID's are separated by commas when using multiple values, see example`,
 		Example: `
 	privx-cli users show [access flags] --id <USER-ID>,<USER-ID>
+	privx-cli users show [access flags] --id <USER-ID> --watch
+	privx-cli users show [access flags] --id <USER-ID>,<USER-ID> --concurrency 16
 		`,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -94,24 +474,73 @@ func userShowCmd() *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.StringVar(&options.userID, "id", "", "user ID")
+	flags.BoolVar(&options.watch, "watch", false, "watch for changes and stream them as newline-delimited JSON events")
+	flags.DurationVar(&options.interval, "interval", 5*time.Second, "polling interval used with --watch")
+	flags.IntVar(&options.concurrency, "concurrency", 8, "number of IDs looked up concurrently")
+	flags.BoolVar(&options.failFast, "fail-fast", false, "abort on the first error instead of reporting per-ID results")
 	cmd.MarkFlagRequired("id")
 
 	return cmd
 }
 
+// userShowResult is the structured per-ID outcome of `users show`.
+type userShowResult struct {
+	ID    string          `json:"id"`
+	OK    bool            `json:"ok"`
+	Error string          `json:"error,omitempty"`
+	User  *rolestore.User `json:"user,omitempty"`
+}
+
 func userShow(options userOptions) error {
 	api := rolestore.New(curl())
-	users := []rolestore.User{}
+	ids := strings.Split(options.userID, ",")
 
-	for _, id := range strings.Split(options.userID, ",") {
-		user, err := api.User(id)
+	if options.watch {
+		return watchLoop(options.interval, func() (map[string]watchRecord, error) {
+			return snapshotUserIDs(api, ids)
+		})
+	}
+
+	if options.failFast {
+		users := []rolestore.User{}
+		for _, id := range ids {
+			user, err := api.User(id)
+			if err != nil {
+				return err
+			}
+			users = append(users, *user)
+		}
+
+		return render(users, "id", "username", "email", "full_name")
+	}
+
+	report := make([]userShowResult, len(ids))
+	failed := 0
+
+	runBounded(len(ids), options.concurrency, func(i int) {
+		user, err := api.User(ids[i])
+		report[i] = userShowResult{ID: ids[i], OK: err == nil}
 		if err != nil {
-			return err
+			report[i].Error = err.Error()
+			return
 		}
-		users = append(users, *user)
+		report[i].User = user
+	})
+
+	for _, result := range report {
+		if !result.OK {
+			failed++
+		}
+	}
+
+	if err := render(report, "id", "ok", "error", "user"); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d IDs failed, see report for details", failed, len(report))
 	}
 
-	return stdout(users)
+	return nil
 }
 
 //
@@ -147,7 +576,7 @@ func userSettingShow(options userOptions) error {
 		return err
 	}
 
-	return stdout(settings)
+	return render(settings)
 }
 
 //
@@ -219,6 +648,8 @@ func usersRolesCmd() *cobra.Command {
 	flags.StringArrayVar(&options.userRoleRevoke, "revoke", []string{}, "revoke role from user, requires role unique id.")
 	cmd.MarkFlagRequired("id")
 
+	cmd.AddCommand(usersRolesApplyCmd())
+
 	return cmd
 }
 
@@ -243,7 +674,193 @@ func userRoles(options userOptions) error {
 	if err != nil {
 		return err
 	}
-	return stdout(roles)
+	return render(roles, "id", "name")
+}
+
+//
+//
+func usersRolesApplyCmd() *cobra.Command {
+	options := userOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Reconcile user role membership from a desired-state file",
+		Long: `Reconcile user role membership from a desired-state file. The file is a
+YAML or JSON mapping of user id or username to a list of role names or ids,
+for example: {"alice": ["auditor", "connection-manager"]}.`,
+		Example: `
+	privx-cli users roles apply [access flags] --file <DESIRED-STATE-FILE>
+	privx-cli users roles apply [access flags] --file <DESIRED-STATE-FILE> --prune
+	privx-cli users roles apply [access flags] --file <DESIRED-STATE-FILE> --dry-run
+		`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return usersRolesApply(options)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.file, "file", "", "YAML or JSON desired-state file")
+	flags.BoolVar(&options.prune, "prune", false, "revoke roles that are not listed in the desired state")
+	flags.BoolVar(&options.dryRun, "dry-run", false, "print the planned grants/revokes without executing them")
+	flags.BoolVar(&options.continueOnErr, "continue-on-error", false, "keep reconciling other users after an error and report it in the summary")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// roleChangeReport is the per-user outcome of a `users roles apply` run.
+type roleChangeReport struct {
+	User    string   `json:"user"`
+	Granted []string `json:"granted,omitempty"`
+	Revoked []string `json:"revoked,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+func usersRolesApply(options userOptions) error {
+	desired, err := decodeDesiredRoles(options.file)
+	if err != nil {
+		return err
+	}
+
+	api := rolestore.New(curl())
+	report := make([]roleChangeReport, 0, len(desired))
+	failed := false
+
+	for user, wantRoleNames := range desired {
+		userID, err := resolveUserID(api, user)
+		if err != nil {
+			if !options.continueOnErr {
+				return err
+			}
+			failed = true
+			report = append(report, roleChangeReport{User: user, Error: err.Error()})
+			continue
+		}
+
+		wantIDs, err := resolveRoleNamesOrIDs(api, wantRoleNames)
+		if err != nil {
+			if !options.continueOnErr {
+				return err
+			}
+			failed = true
+			report = append(report, roleChangeReport{User: user, Error: err.Error()})
+			continue
+		}
+
+		current, err := api.UserRoles(userID)
+		if err != nil {
+			if !options.continueOnErr {
+				return err
+			}
+			failed = true
+			report = append(report, roleChangeReport{User: user, Error: err.Error()})
+			continue
+		}
+
+		currentIDs := make(map[string]bool, len(current))
+		for _, role := range current {
+			currentIDs[role.ID] = true
+		}
+		wantSet := make(map[string]bool, len(wantIDs))
+		for _, id := range wantIDs {
+			wantSet[id] = true
+		}
+
+		entry := roleChangeReport{User: user}
+
+		for _, id := range wantIDs {
+			if currentIDs[id] {
+				continue
+			}
+			if options.dryRun {
+				entry.Granted = append(entry.Granted, id)
+				continue
+			}
+			if err := api.GrantUserRole(userID, id); err != nil {
+				if !options.continueOnErr {
+					return err
+				}
+				failed = true
+				entry.Error = err.Error()
+				continue
+			}
+			entry.Granted = append(entry.Granted, id)
+		}
+
+		if options.prune {
+			for _, role := range current {
+				if wantSet[role.ID] {
+					continue
+				}
+				if options.dryRun {
+					entry.Revoked = append(entry.Revoked, role.ID)
+					continue
+				}
+				if err := api.RevokeUserRole(userID, role.ID); err != nil {
+					if !options.continueOnErr {
+						return err
+					}
+					failed = true
+					entry.Error = err.Error()
+					continue
+				}
+				entry.Revoked = append(entry.Revoked, role.ID)
+			}
+		}
+
+		report = append(report, entry)
+	}
+
+	if err := render(report, "user", "granted", "revoked", "error"); err != nil {
+		return err
+	}
+
+	if failed {
+		return fmt.Errorf("users roles apply: one or more users failed to reconcile")
+	}
+
+	return nil
+}
+
+func decodeDesiredRoles(name string) (map[string][]string, error) {
+	var desired map[string][]string
+
+	ext := strings.ToLower(filepath.Ext(name))
+	if ext == ".yaml" || ext == ".yml" {
+		data, err := ioutil.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(data, &desired); err != nil {
+			return nil, err
+		}
+		return desired, nil
+	}
+
+	err := decodeJSON(name, &desired)
+	return desired, err
+}
+
+// resolveUserID resolves a user id or username to a user unique id.
+func resolveUserID(api *rolestore.RoleStore, user string) (string, error) {
+	if uuidPattern.MatchString(user) {
+		return user, nil
+	}
+
+	users, err := api.SearchUsers(user, "")
+	if err != nil {
+		return "", err
+	}
+
+	for _, candidate := range users {
+		if candidate.Username == user {
+			return candidate.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("user %q not found", user)
 }
 
 //
@@ -257,6 +874,8 @@ func userMFACmd() *cobra.Command {
 		Long:  `Enable, disable or reset multifactor authentication. User ID's are separated by commas when using multiple values, see example`,
 		Example: `
 	privx-cli users mfa [access flags] --id <USER-ID>,<USER-ID> --enable
+	privx-cli users mfa [access flags] --id <USER-ID>,<USER-ID> --reset --concurrency 16
+	privx-cli users mfa [access flags] --id <USER-ID>,<USER-ID> --reset --fail-fast
 		`,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -269,6 +888,8 @@ func userMFACmd() *cobra.Command {
 	flags.BoolVarP(&options.enable, "enable", "e", false, "turn on multifactor authentication")
 	flags.BoolVarP(&options.disable, "disable", "d", false, "turn off multifactor authentication")
 	flags.BoolVarP(&options.reset, "reset", "r", false, "reset multifactor authentication")
+	flags.IntVar(&options.concurrency, "concurrency", 8, "number of IDs processed concurrently")
+	flags.BoolVar(&options.failFast, "fail-fast", false, "abort on the first error instead of reporting per-ID results")
 	cmd.MarkFlagRequired("id")
 
 	return cmd
@@ -276,50 +897,60 @@ func userMFACmd() *cobra.Command {
 
 func userMFA(options userOptions) error {
 	if options.enable {
-		enableMFA(options)
+		return enableMFA(options)
 	} else if options.disable {
-		disableMFA(options)
+		return disableMFA(options)
 	} else if options.reset {
-		resetMFA(options)
-	} else {
-		fmt.Fprintln(os.Stderr, "Error: you have to specify one of the following flag: --enable, --disable or --reset")
-		os.Exit(1)
+		return resetMFA(options)
 	}
 
+	fmt.Fprintln(os.Stderr, "Error: you have to specify one of the following flag: --enable, --disable or --reset")
+	os.Exit(1)
+
 	return nil
 }
 
 func enableMFA(options userOptions) error {
 	api := rolestore.New(curl())
-
-	err := api.EnableMFA(strings.Split(options.userID, ","))
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return fanOutMFA(options, api.EnableMFA)
 }
 
 func disableMFA(options userOptions) error {
 	api := rolestore.New(curl())
-
-	err := api.DisableMFA(strings.Split(options.userID, ","))
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return fanOutMFA(options, api.DisableMFA)
 }
 
 func resetMFA(options userOptions) error {
 	api := rolestore.New(curl())
+	return fanOutMFA(options, api.ResetMFA)
+}
 
-	err := api.ResetMFA(strings.Split(options.userID, ","))
-	if err != nil {
+// fanOutMFA runs a single-ID-at-a-time MFA operation (enable, disable or
+// reset) over every ID in options.userID through a bounded worker pool,
+// unless --fail-fast asks for the old behavior: a single call with every ID
+// whose error is returned as is, without a per-ID report.
+func fanOutMFA(options userOptions, op func([]string) error) error {
+	ids := strings.Split(options.userID, ",")
+
+	if options.failFast {
+		return op(ids)
+	}
+
+	report := make([]idResult, len(ids))
+
+	runBounded(len(ids), options.concurrency, func(i int) {
+		err := op([]string{ids[i]})
+		report[i] = idResult{ID: ids[i], OK: err == nil}
+		if err != nil {
+			report[i].Error = err.Error()
+		}
+	})
+
+	if err := render(report, "id", "ok", "error"); err != nil {
 		return err
 	}
 
-	return nil
+	return reportErr(report)
 }
 
 //
@@ -356,7 +987,218 @@ func externalUserSearch(options userOptions) error {
 		return err
 	}
 
-	return stdout(users)
+	return render(users, "id", "username", "email")
+}
+
+//
+//
+func userCreateCmd() *cobra.Command {
+	options := userOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Bulk create local users from a CSV or JSON file",
+		Long: `Bulk create local users from a CSV or JSON file. Columns/fields are
+username,email,full_name,roles,mfa_enabled,source_id. Roles are separated by
+";" in a CSV file and given as an array in a JSON file, and may be given
+either by name or by unique id.`,
+		Example: `
+	privx-cli users create [access flags] --file <CSV-OR-JSON-FILE>
+	privx-cli users create [access flags] --file <JSON-FILE> --format json
+	privx-cli users create [access flags] --file <CSV-FILE> --dry-run
+		`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return userCreate(options)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.file, "file", "", "CSV or JSON file with the users to create")
+	flags.StringVar(&options.format, "format", "", "input file format, csv or json, autodetected from the file extension when empty")
+	flags.BoolVar(&options.dryRun, "dry-run", false, "print the planned API calls instead of executing them")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// userRecord is one row of a bulk user-creation file.
+type userRecord struct {
+	Username   string   `json:"username"`
+	Email      string   `json:"email"`
+	FullName   string   `json:"full_name"`
+	Roles      []string `json:"roles"`
+	MFAEnabled bool     `json:"mfa_enabled"`
+	SourceID   string   `json:"source_id"`
+}
+
+func userCreate(options userOptions) error {
+	format := options.format
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(options.file)), ".")
+	}
+
+	var records []userRecord
+	var err error
+
+	switch format {
+	case "json":
+		records, err = decodeUserRecordsJSON(options.file)
+	case "csv":
+		records, err = decodeUserRecordsCSV(options.file)
+	default:
+		return fmt.Errorf("unsupported file format %q, expected csv or json", format)
+	}
+	if err != nil {
+		return err
+	}
+
+	api := rolestore.New(curl())
+
+	roleIDs, err := resolveRoleIDs(api, records)
+	if err != nil {
+		return err
+	}
+
+	created := []rolestore.User{}
+
+	for _, record := range records {
+		user := rolestore.User{
+			Username: record.Username,
+			Email:    record.Email,
+			FullName: record.FullName,
+			SourceID: record.SourceID,
+		}
+
+		for _, role := range record.Roles {
+			user.Roles = append(user.Roles, rolestore.Role{ID: roleIDs[role]})
+		}
+
+		if options.dryRun {
+			fmt.Fprintf(os.Stderr, "DRY-RUN: create user %q with roles %v\n", user.Username, record.Roles)
+			if record.MFAEnabled {
+				fmt.Fprintf(os.Stderr, "DRY-RUN: enable MFA for user %q\n", user.Username)
+			}
+			continue
+		}
+
+		id, err := api.CreateUser(&user)
+		if err != nil {
+			return err
+		}
+
+		if record.MFAEnabled {
+			if err := api.EnableMFA([]string{id}); err != nil {
+				return err
+			}
+		}
+
+		user.ID = id
+		created = append(created, user)
+	}
+
+	if options.dryRun {
+		return nil
+	}
+
+	return render(created, "id", "username", "email")
+}
+
+func decodeUserRecordsJSON(name string) ([]userRecord, error) {
+	var records []userRecord
+	err := decodeJSON(name, &records)
+	return records, err
+}
+
+func decodeUserRecordsCSV(name string) ([]userRecord, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	header := rows[0]
+	column := make(map[string]int, len(header))
+	for i, name := range header {
+		column[strings.TrimSpace(name)] = i
+	}
+
+	records := make([]userRecord, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		record := userRecord{
+			Username: cellAt(row, column, "username"),
+			Email:    cellAt(row, column, "email"),
+			FullName: cellAt(row, column, "full_name"),
+			SourceID: cellAt(row, column, "source_id"),
+		}
+
+		if roles := cellAt(row, column, "roles"); roles != "" {
+			for _, role := range strings.Split(roles, ";") {
+				role = strings.TrimSpace(role)
+				if role != "" {
+					record.Roles = append(record.Roles, role)
+				}
+			}
+		}
+
+		if mfa := cellAt(row, column, "mfa_enabled"); mfa != "" {
+			record.MFAEnabled, err = strconv.ParseBool(mfa)
+			if err != nil {
+				return nil, fmt.Errorf("invalid mfa_enabled value %q: %w", mfa, err)
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func cellAt(row []string, column map[string]int, name string) string {
+	i, ok := column[name]
+	if !ok || i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+// resolveRoleIDs resolves every role referenced by records, either by name
+// or by unique id, to its unique id.
+func resolveRoleIDs(api *rolestore.RoleStore, records []userRecord) (map[string]string, error) {
+	ids := map[string]string{}
+
+	for _, record := range records {
+		for _, role := range record.Roles {
+			if _, ok := ids[role]; ok {
+				continue
+			}
+			if uuidPattern.MatchString(role) {
+				ids[role] = role
+				continue
+			}
+
+			roles, err := api.Roles(role, "")
+			if err != nil {
+				return nil, err
+			}
+			if len(roles) == 0 {
+				return nil, fmt.Errorf("role %q not found", role)
+			}
+
+			ids[role] = roles[0].ID
+		}
+	}
+
+	return ids, nil
 }
 
 func decodeJSON(name string, object interface{}) error {